@@ -0,0 +1,179 @@
+package dqlx
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BatchExecutor wraps a DGoExecutor to drive long-running mutation
+// pipelines -- e.g. streaming a large batch of MutationBuilders in over
+// ExecuteMutations -- with independent read/write deadlines, so a stalled
+// producer or a stalled Dgraph response aborts the pipeline cleanly
+// instead of leaking the goroutine blocked in tx.Do.
+//
+// The deadline bookkeeping follows the same shared cancel-channel +
+// time.AfterFunc pattern net.Pipe uses internally: SetReadDeadline and
+// SetWriteDeadline each own a channel that is closed when their deadline
+// elapses, and is replaced by a fresh one when the deadline is reset or
+// cleared after it has elapsed.
+type BatchExecutor struct {
+	executor *DGoExecutor
+
+	readDeadline  pipelineDeadline
+	writeDeadline pipelineDeadline
+}
+
+// NewBatchExecutor wraps the given executor for deadline-aware batched
+// mutation execution.
+func NewBatchExecutor(executor *DGoExecutor) *BatchExecutor {
+	return &BatchExecutor{
+		executor:      executor,
+		readDeadline:  makePipelineDeadline(),
+		writeDeadline: makePipelineDeadline(),
+	}
+}
+
+// SetReadDeadline bounds how long RunBatch will wait for the next batch
+// to be produced before aborting the pipeline. A zero value clears it.
+func (batchExecutor *BatchExecutor) SetReadDeadline(t time.Time) {
+	batchExecutor.readDeadline.set(t)
+}
+
+// SetWriteDeadline bounds how long RunBatch will wait for a single
+// ExecuteMutations call to Dgraph to complete before aborting the
+// pipeline. A zero value clears it.
+func (batchExecutor *BatchExecutor) SetWriteDeadline(t time.Time) {
+	batchExecutor.writeDeadline.set(t)
+}
+
+// RunBatch pulls batches of mutations from next until it returns false,
+// executing each batch via the wrapped DGoExecutor. It returns early,
+// without leaking the in-flight request, if either the read or the
+// write deadline elapses.
+func (batchExecutor *BatchExecutor) RunBatch(ctx context.Context, next func() (batch []MutationBuilder, ok bool)) error {
+	for {
+		type pulled struct {
+			batch []MutationBuilder
+			ok    bool
+		}
+
+		pulledCh := make(chan pulled, 1)
+		go func() {
+			batch, ok := next()
+			pulledCh <- pulled{batch: batch, ok: ok}
+		}()
+
+		select {
+		case <-batchExecutor.readDeadline.wait():
+			return context.DeadlineExceeded
+		case result := <-pulledCh:
+			if !result.ok {
+				return nil
+			}
+
+			if err := batchExecutor.executeBatch(ctx, result.batch); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (batchExecutor *BatchExecutor) executeBatch(ctx context.Context, batch []MutationBuilder) error {
+	ctx, cancel := batchExecutor.writeDeadline.withDeadline(ctx)
+	defer cancel()
+
+	doneCh := make(chan error, 1)
+
+	go func() {
+		_, err := batchExecutor.executor.ExecuteMutations(ctx, batch...)
+		doneCh <- err
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-doneCh:
+		return err
+	}
+}
+
+// pipelineDeadline is a cancel channel that is closed once its deadline
+// elapses; set replaces it with a fresh channel once it has been closed,
+// so a past deadline can be reset to a future one. Modelled on the
+// deadline type net.Pipe uses.
+type pipelineDeadline struct {
+	mu     sync.Mutex
+	timer  *time.Timer
+	cancel chan struct{}
+	at     time.Time
+}
+
+func makePipelineDeadline() pipelineDeadline {
+	return pipelineDeadline{cancel: make(chan struct{})}
+}
+
+func (d *pipelineDeadline) set(t time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.timer != nil && !d.timer.Stop() {
+		<-d.cancel
+	}
+	d.timer = nil
+	d.at = t
+
+	closed := isClosedChan(d.cancel)
+
+	if t.IsZero() {
+		if closed {
+			d.cancel = make(chan struct{})
+		}
+		return
+	}
+
+	if dur := time.Until(t); dur > 0 {
+		if closed {
+			d.cancel = make(chan struct{})
+		}
+		cancel := d.cancel
+		d.timer = time.AfterFunc(dur, func() { close(cancel) })
+		return
+	}
+
+	if !closed {
+		close(d.cancel)
+	}
+}
+
+func (d *pipelineDeadline) wait() chan struct{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.cancel
+}
+
+// withDeadline derives a child of parent bounded by the deadline, if any,
+// currently configured via set. Unlike wait(), which only tells the
+// caller a deadline elapsed, this lets ctx itself carry the deadline so
+// it can be threaded into the in-flight request and actually cancel it
+// instead of merely abandoning the caller's wait.
+func (d *pipelineDeadline) withDeadline(parent context.Context) (context.Context, context.CancelFunc) {
+	d.mu.Lock()
+	at := d.at
+	d.mu.Unlock()
+
+	if at.IsZero() {
+		return parent, func() {}
+	}
+
+	return context.WithDeadline(parent, at)
+}
+
+func isClosedChan(c chan struct{}) bool {
+	select {
+	case <-c:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,64 @@
+package dqlx
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPipelineDeadlineWithDeadline(t *testing.T) {
+	t.Run("no deadline set", func(t *testing.T) {
+		d := makePipelineDeadline()
+
+		ctx, cancel := d.withDeadline(context.Background())
+		defer cancel()
+
+		if _, ok := ctx.Deadline(); ok {
+			t.Fatal("expected no deadline on returned context")
+		}
+	})
+
+	t.Run("future deadline cancels ctx when it elapses", func(t *testing.T) {
+		d := makePipelineDeadline()
+		d.set(time.Now().Add(20 * time.Millisecond))
+
+		ctx, cancel := d.withDeadline(context.Background())
+		defer cancel()
+
+		select {
+		case <-ctx.Done():
+			if ctx.Err() != context.DeadlineExceeded {
+				t.Fatalf("ctx.Err() = %v, want DeadlineExceeded", ctx.Err())
+			}
+		case <-time.After(time.Second):
+			t.Fatal("ctx was not cancelled after its deadline elapsed")
+		}
+	})
+
+	t.Run("past deadline cancels immediately", func(t *testing.T) {
+		d := makePipelineDeadline()
+		d.set(time.Now().Add(-time.Second))
+
+		ctx, cancel := d.withDeadline(context.Background())
+		defer cancel()
+
+		select {
+		case <-ctx.Done():
+		default:
+			t.Fatal("expected ctx to already be done for a past deadline")
+		}
+	})
+
+	t.Run("clearing the deadline removes it from new contexts", func(t *testing.T) {
+		d := makePipelineDeadline()
+		d.set(time.Now().Add(time.Minute))
+		d.set(time.Time{})
+
+		ctx, cancel := d.withDeadline(context.Background())
+		defer cancel()
+
+		if _, ok := ctx.Deadline(); ok {
+			t.Fatal("expected no deadline on returned context after clearing")
+		}
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/dgraph-io/dgo/v200/protos/api"
 	"github.com/mitchellh/mapstructure"
 	"reflect"
+	"strings"
 	"time"
 )
 
@@ -17,6 +18,9 @@ type DGoExecutor struct {
 
 	readOnly   bool
 	bestEffort bool
+
+	timeout  time.Duration
+	deadline time.Time
 }
 
 type ExecutorOptionFn func(executor *DGoExecutor)
@@ -45,6 +49,26 @@ func WithBestEffort(bestEffort bool) ExecutorOptionFn {
 	}
 }
 
+// WithTimeout bounds every query/mutation executed by this executor to the
+// given duration, independently of the deadline already carried by the
+// ctx passed to ExecuteQueries/ExecuteMutations. The shorter of the two
+// deadlines wins.
+func WithTimeout(timeout time.Duration) ExecutorOptionFn {
+	return func(executor *DGoExecutor) {
+		executor.timeout = timeout
+	}
+}
+
+// WithDeadline bounds every query/mutation executed by this executor to
+// the given point in time, independently of the deadline already carried
+// by the ctx passed to ExecuteQueries/ExecuteMutations. The earlier of
+// the two deadlines wins.
+func WithDeadline(deadline time.Time) ExecutorOptionFn {
+	return func(executor *DGoExecutor) {
+		executor.deadline = deadline
+	}
+}
+
 func NewDGoExecutor(client *dgo.Dgraph) *DGoExecutor {
 	return &DGoExecutor{
 		client: client,
@@ -61,6 +85,9 @@ func (executor DGoExecutor) ExecuteQueries(ctx context.Context, queries ...Query
 		return nil, err
 	}
 
+	ctx, cancel := executor.withDeadline(ctx)
+	defer cancel()
+
 	tx := executor.getTnx()
 
 	defer tx.Discard(ctx)
@@ -109,6 +136,8 @@ func (executor DGoExecutor) ExecuteMutations(ctx context.Context, mutations ...M
 		mutationRequest := &api.Mutation{
 			SetJson:    setData,
 			DeleteJson: deleteData,
+			SetNquads:  []byte(mutation.setNQuads),
+			DelNquads:  []byte(mutation.delNQuads),
 			Cond:       condition,
 			CommitNow:  executor.tnx == nil,
 		}
@@ -133,6 +162,9 @@ func (executor DGoExecutor) ExecuteMutations(ctx context.Context, mutations ...M
 		RespFormat: api.Request_JSON,
 	}
 
+	ctx, cancel := executor.withDeadline(ctx)
+	defer cancel()
+
 	tx := executor.getTnx()
 	defer tx.Discard(ctx)
 
@@ -145,6 +177,99 @@ func (executor DGoExecutor) ExecuteMutations(ctx context.Context, mutations ...M
 	return executor.toResponse(resp, queries...)
 }
 
+// ExecutePaginated repeatedly executes query, paginating via first/after
+// on query.pageSize (set with QueryBuilder.Paginate), and streams each
+// page's decoded elements into handler until a page comes back short of
+// a full page. target is passed straight through to Response.Stream, and
+// must decode into a struct exposing a "uid" json field -- that value is
+// used as the next page's after cursor, and its absence aborts the
+// pagination with an error rather than silently stopping after one page.
+func (executor DGoExecutor) ExecutePaginated(ctx context.Context, query QueryBuilder, target interface{}, handler func(item interface{}) error) error {
+	if query.pageSize <= 0 {
+		return errors.New("dqlx: ExecutePaginated requires QueryBuilder.Paginate to be set")
+	}
+
+	after := ""
+	page := query.First(query.pageSize)
+
+	for {
+		pageQuery := page
+		if after != "" {
+			pageQuery = pageQuery.After(after)
+		}
+
+		resp, err := executor.ExecuteQueries(ctx, pageQuery)
+		if err != nil {
+			return err
+		}
+
+		count := 0
+		lastUID := ""
+
+		err = resp.Stream(target, func(item interface{}) error {
+			count++
+
+			uid, ok := cursorUID(item)
+			if !ok {
+				return errors.New("dqlx: ExecutePaginated requires the decoded item to have a \"uid\" json field to use as the next page's cursor")
+			}
+			lastUID = uid
+
+			return handler(item)
+		})
+		if err != nil {
+			return err
+		}
+
+		if count < query.pageSize {
+			return nil
+		}
+
+		after = lastUID
+	}
+}
+
+// cursorUID extracts the "uid" field dqlx/Dgraph populates on every
+// decoded node via reflection, since ordinary query targets are plain
+// structs with a UID field tagged json:"uid" rather than a GetUID()
+// method. ok is false when item has no such field or its value is
+// empty, which ExecutePaginated treats as "no cursor available".
+func cursorUID(item interface{}) (uid string, ok bool) {
+	value := reflect.ValueOf(item)
+
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return "", false
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return "", false
+	}
+
+	structType := value.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tagName := strings.Split(field.Tag.Get("json"), ",")[0]
+
+		isUIDField := tagName == "uid" || (tagName == "" && strings.EqualFold(field.Name, "uid"))
+		if !isUIDField {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		if fieldValue.Kind() != reflect.String {
+			return "", false
+		}
+
+		return fieldValue.String(), fieldValue.String() != ""
+	}
+
+	return "", false
+}
+
 func (executor DGoExecutor) toResponse(resp *api.Response, queries ...QueryBuilder) (*Response, error) {
 	var dataPathKey string
 
@@ -203,6 +328,27 @@ func mutationData(mutation MutationBuilder) (updateData []byte, deleteData []byt
 	return setDataBytes, deleteDataBytes, nil
 }
 
+// withDeadline wraps ctx with the earlier of the WithTimeout/WithDeadline
+// bounds configured on the executor, independently of any deadline ctx
+// already carries. The returned cancel func must always be called; it is
+// a no-op when neither option was set.
+func (executor DGoExecutor) withDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadline := executor.deadline
+
+	if executor.timeout > 0 {
+		timeoutDeadline := time.Now().Add(executor.timeout)
+		if deadline.IsZero() || timeoutDeadline.Before(deadline) {
+			deadline = timeoutDeadline
+		}
+	}
+
+	if deadline.IsZero() {
+		return ctx, func() {}
+	}
+
+	return context.WithDeadline(ctx, deadline)
+}
+
 func (executor DGoExecutor) ensureClient() error {
 	if executor.client == nil {
 		return errors.New("cannot execute query without setting a dqlx. use DClient() to set one")
@@ -256,4 +402,4 @@ func (response Response) Unmarshal(value interface{}) error {
 	}
 
 	return decoder.Decode(values)
-}
\ No newline at end of file
+}
@@ -0,0 +1,52 @@
+package dqlx
+
+import "testing"
+
+func TestCursorUID(t *testing.T) {
+	type taggedUID struct {
+		UID  string `json:"uid"`
+		Name string `json:"name"`
+	}
+
+	type untaggedUID struct {
+		UID  string
+		Name string
+	}
+
+	type noUID struct {
+		Name string
+	}
+
+	type nonStringUID struct {
+		UID  int `json:"uid"`
+		Name string
+	}
+
+	cases := []struct {
+		name    string
+		item    interface{}
+		wantUID string
+		wantOK  bool
+	}{
+		{"tagged uid field", &taggedUID{UID: "0x1", Name: "a"}, "0x1", true},
+		{"untagged UID field", &untaggedUID{UID: "0x2", Name: "b"}, "0x2", true},
+		{"empty uid value", &taggedUID{UID: "", Name: "c"}, "", false},
+		{"no uid field", &noUID{Name: "d"}, "", false},
+		{"non-string uid field", &nonStringUID{UID: 1, Name: "e"}, "", false},
+		{"nil pointer", (*taggedUID)(nil), "", false},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			uid, ok := cursorUID(testCase.item)
+
+			if ok != testCase.wantOK {
+				t.Fatalf("cursorUID() ok = %v, want %v", ok, testCase.wantOK)
+			}
+
+			if uid != testCase.wantUID {
+				t.Fatalf("cursorUID() uid = %q, want %q", uid, testCase.wantUID)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Package graphql turns a GraphQL schema into a resolver that serves
+// queries straight off Dgraph via dqlx, without hand-written resolver
+// functions. A schema is parsed once with gqlparser; every incoming
+// GraphQL query is then translated into a dqlx.QueryBuilder tree,
+// executed through a dqlx.DGoExecutor, and the raw DQL JSON response is
+// reshaped back into the selection the caller asked for.
+package graphql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/allanglen/dqlx"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Resolver serves GraphQL operations against a Dgraph instance by
+// translating them into DQL queries defined by the given schema.
+type Resolver struct {
+	schema   *ast.Schema
+	executor *dqlx.DGoExecutor
+}
+
+// NewResolver parses schemaSource with gqlparser and binds the result to
+// executor. It returns an error if the schema fails to parse.
+func NewResolver(schemaSource string, executor *dqlx.DGoExecutor) (*Resolver, error) {
+	schema, err := gqlparser.LoadSchema(&ast.Source{
+		Name:  "schema.graphql",
+		Input: schemaSource,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Resolver{
+		schema:   schema,
+		executor: executor,
+	}, nil
+}
+
+// Execute runs a GraphQL query document against Dgraph and returns the
+// decoded result shaped as a GraphQL response ({"data": {...}}).
+// operationName selects which operation to run when query declares more
+// than one; it may be left empty when query declares exactly one.
+func (resolver *Resolver) Execute(ctx context.Context, query string, operationName string, variables map[string]interface{}) (map[string]interface{}, error) {
+	doc, err := gqlparser.LoadQuery(resolver.schema, query)
+	if err != nil {
+		return nil, err
+	}
+
+	operation, err := selectOperation(doc.Operations, operationName)
+	if err != nil {
+		return nil, err
+	}
+
+	if operation.Operation != ast.Query {
+		return nil, fmt.Errorf("dqlx/graphql: %s operations are not supported yet", operation.Operation)
+	}
+
+	builders := make([]dqlx.QueryBuilder, len(operation.SelectionSet))
+	fields := make([]*ast.Field, len(operation.SelectionSet))
+
+	for index, selection := range operation.SelectionSet {
+		field, ok := selection.(*ast.Field)
+		if !ok {
+			return nil, fmt.Errorf("dqlx/graphql: unsupported root selection %T", selection)
+		}
+
+		builder, err := fieldToQuery(field, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		builders[index] = builder
+		fields[index] = field
+	}
+
+	resp, err := resolver.executor.ExecuteQueries(ctx, builders...)
+	if err != nil {
+		return nil, err
+	}
+
+	return assembleResponse(resp, fields)
+}
+
+// selectOperation picks the operation Execute should run out of a parsed
+// query document. With a single operation, operationName may be left
+// empty; with several, it must name one of them.
+func selectOperation(operations ast.OperationList, operationName string) (*ast.OperationDefinition, error) {
+	if operationName == "" {
+		if len(operations) != 1 {
+			return nil, fmt.Errorf("dqlx/graphql: operationName is required when the document declares more than one operation")
+		}
+
+		return operations[0], nil
+	}
+
+	for _, operation := range operations {
+		if operation.Name == operationName {
+			return operation, nil
+		}
+	}
+
+	return nil, fmt.Errorf("dqlx/graphql: no operation named %q in the query document", operationName)
+}
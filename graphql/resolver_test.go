@@ -0,0 +1,72 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func loadOperations(t *testing.T, query string) ast.OperationList {
+	t.Helper()
+
+	schema, err := gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: testSchema})
+	if err != nil {
+		t.Fatalf("failed to load schema: %v", err)
+	}
+
+	doc, err := gqlparser.LoadQuery(schema, query)
+	if err != nil {
+		t.Fatalf("failed to load query: %v", err)
+	}
+
+	return doc.Operations
+}
+
+func TestSelectOperationSingleOperation(t *testing.T) {
+	operations := loadOperations(t, `{ users { name } }`)
+
+	operation, err := selectOperation(operations, "")
+	if err != nil {
+		t.Fatalf("selectOperation() error = %v", err)
+	}
+
+	if operation != operations[0] {
+		t.Fatalf("selectOperation() returned a different operation than the sole one in the document")
+	}
+}
+
+func TestSelectOperationByName(t *testing.T) {
+	operations := loadOperations(t, `
+query First { users { name } }
+query Second { users { role } }
+`)
+
+	operation, err := selectOperation(operations, "Second")
+	if err != nil {
+		t.Fatalf("selectOperation() error = %v", err)
+	}
+
+	if operation.Name != "Second" {
+		t.Fatalf("operation.Name = %q, want %q", operation.Name, "Second")
+	}
+}
+
+func TestSelectOperationRequiresNameWithMultipleOperations(t *testing.T) {
+	operations := loadOperations(t, `
+query First { users { name } }
+query Second { users { role } }
+`)
+
+	if _, err := selectOperation(operations, ""); err == nil {
+		t.Fatal("expected an error when operationName is empty and the document declares more than one operation")
+	}
+}
+
+func TestSelectOperationUnknownName(t *testing.T) {
+	operations := loadOperations(t, `{ users { name } }`)
+
+	if _, err := selectOperation(operations, "DoesNotExist"); err == nil {
+		t.Fatal("expected an error for an unknown operation name")
+	}
+}
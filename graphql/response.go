@@ -0,0 +1,88 @@
+package graphql
+
+import (
+	"encoding/json"
+
+	"github.com/allanglen/dqlx"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// assembleResponse reshapes the raw DQL JSON response into a GraphQL
+// response ({"data": {...}}), renaming every key, at every nesting
+// depth, to the alias the caller actually asked for. Edges are keyed by
+// alias already (fieldToQuery queries them under it directly), but
+// scalar leaves are always queried under their real predicate name and
+// need renaming here -- see reshapeObject.
+func assembleResponse(resp *dqlx.Response, fields []*ast.Field) (map[string]interface{}, error) {
+	var raw map[string]interface{}
+
+	if err := json.Unmarshal(resp.Raw.Json, &raw); err != nil {
+		return nil, err
+	}
+
+	selectionSet := make(ast.SelectionSet, len(fields))
+	for index, field := range fields {
+		selectionSet[index] = field
+	}
+
+	return map[string]interface{}{"data": reshapeObject(raw, selectionSet)}, nil
+}
+
+// reshapeObject renames every key of raw to the alias requested by the
+// matching field in selectionSet, recursing into nested edges so
+// aliases at any depth survive the round trip through DQL.
+func reshapeObject(raw map[string]interface{}, selectionSet ast.SelectionSet) map[string]interface{} {
+	reshaped := map[string]interface{}{}
+
+	for _, selection := range selectionSet {
+		field, ok := selection.(*ast.Field)
+		if !ok {
+			continue
+		}
+
+		rawKey := field.Name
+		if len(field.SelectionSet) > 0 {
+			// Edges are queried under their alias directly (fieldToQuery
+			// passes field.Alias as the block's own name/label), unlike
+			// scalar leaves, which dqlx always projects by their real
+			// predicate name.
+			rawKey = fieldKey(field)
+		}
+
+		reshaped[fieldKey(field)] = reshapeValue(raw[rawKey], field.SelectionSet)
+	}
+
+	return reshaped
+}
+
+// reshapeValue applies reshapeObject across value, which is either a
+// single edge/object, a list of them, or (when selectionSet is empty,
+// i.e. value is a scalar) passed through unchanged.
+func reshapeValue(value interface{}, selectionSet ast.SelectionSet) interface{} {
+	if len(selectionSet) == 0 {
+		return value
+	}
+
+	switch typed := value.(type) {
+	case []interface{}:
+		items := make([]interface{}, len(typed))
+		for index, item := range typed {
+			items[index] = reshapeValue(item, selectionSet)
+		}
+		return items
+	case map[string]interface{}:
+		return reshapeObject(typed, selectionSet)
+	default:
+		return value
+	}
+}
+
+// fieldKey is the key a field surfaces under in the GraphQL response:
+// its alias when the caller gave it one, its own name otherwise.
+func fieldKey(field *ast.Field) string {
+	if field.Alias != "" {
+		return field.Alias
+	}
+
+	return field.Name
+}
@@ -0,0 +1,50 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/allanglen/dqlx"
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func TestAssembleResponseRenamesNestedAliases(t *testing.T) {
+	field := parseField(t, `{ f: users { n: name bestFriend: friend { n: name } } }`)
+
+	resp := &dqlx.Response{Raw: &api.Response{
+		Json: []byte(`{"f":[{"name":"a","bestFriend":{"name":"b"}}]}`),
+	}}
+
+	got, err := assembleResponse(resp, []*ast.Field{field})
+	if err != nil {
+		t.Fatalf("assembleResponse() error = %v", err)
+	}
+
+	data, ok := got["data"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("data = %#v, want a map", got["data"])
+	}
+
+	users, ok := data["f"].([]interface{})
+	if !ok || len(users) != 1 {
+		t.Fatalf("f = %#v, want a single-element list", data["f"])
+	}
+
+	user, ok := users[0].(map[string]interface{})
+	if !ok {
+		t.Fatalf("users[0] = %#v, want a map", users[0])
+	}
+
+	if user["n"] != "a" {
+		t.Fatalf("n = %#v, want %q", user["n"], "a")
+	}
+
+	friend, ok := user["bestFriend"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("bestFriend = %#v, want a map", user["bestFriend"])
+	}
+
+	if friend["n"] != "b" {
+		t.Fatalf("friend.n = %#v, want %q", friend["n"], "b")
+	}
+}
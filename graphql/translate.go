@@ -0,0 +1,174 @@
+package graphql
+
+import (
+	"fmt"
+
+	"github.com/allanglen/dqlx"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// fieldToQuery translates a single root GraphQL field into a dqlx query
+// rooted on that field's name, recursively translating its selection set
+// into Select(...) and its arguments into filters.
+func fieldToQuery(field *ast.Field, variables map[string]interface{}) (dqlx.QueryBuilder, error) {
+	if err := rejectUnsupportedDirectives(field); err != nil {
+		return dqlx.QueryBuilder{}, err
+	}
+
+	builder := dqlx.Query(dqlx.EdgeFromQuery(field.Alias, field.Name))
+
+	selections, edges, err := selectionToFields(field.SelectionSet, variables)
+	if err != nil {
+		return dqlx.QueryBuilder{}, err
+	}
+
+	builder = builder.Select(selections...)
+
+	for _, edge := range edges {
+		builder = builder.Select(edge)
+	}
+
+	filters, err := argsToFilters(field, variables)
+	if err != nil {
+		return dqlx.QueryBuilder{}, err
+	}
+
+	if len(filters) > 0 {
+		builder = builder.Filter(filters...)
+	}
+
+	return builder, nil
+}
+
+// unsupportedDirectives are schema directives this package doesn't
+// translate to DQL yet (facets, @recurse, @shortestPath). Fields
+// declaring one of these fail loudly via rejectUnsupportedDirectives
+// instead of silently returning a query that quietly omits them.
+var unsupportedDirectives = []string{"recurse", "shortestPath", "facets"}
+
+// rejectUnsupportedDirectives errors out for any directive in
+// unsupportedDirectives declared on field's schema definition.
+func rejectUnsupportedDirectives(field *ast.Field) error {
+	for _, name := range unsupportedDirectives {
+		if field.Definition.Directives.ForName(name) != nil {
+			return fmt.Errorf("dqlx/graphql: @%s is not yet supported", name)
+		}
+	}
+
+	return nil
+}
+
+// selectionToFields splits a GraphQL selection set into plain scalar
+// field names and nested dqlx edges (sub-queries built from fields that
+// themselves have a selection set).
+func selectionToFields(set ast.SelectionSet, variables map[string]interface{}) (scalars []interface{}, edges []dqlx.QueryBuilder, err error) {
+	for _, selection := range set {
+		field, ok := selection.(*ast.Field)
+		if !ok {
+			return nil, nil, fmt.Errorf("dqlx/graphql: unsupported selection %T", selection)
+		}
+
+		if len(field.SelectionSet) == 0 {
+			scalars = append(scalars, field.Name)
+			continue
+		}
+
+		edge, err := fieldToQuery(field, variables)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		edges = append(edges, edge)
+	}
+
+	return scalars, edges, nil
+}
+
+// argsToFilters translates a field's GraphQL arguments -- including
+// defaults declared on the schema -- into dqlx filters. Only equality
+// arguments are supported directly; richer comparisons are expressed in
+// the schema via an `@filter` directive argument carrying a raw DQL
+// function name: eq, le, ge, lt or gt. Any other function name is
+// rejected with an error.
+func argsToFilters(field *ast.Field, variables map[string]interface{}) ([]dqlx.DQLizer, error) {
+	var filters []dqlx.DQLizer
+
+	for _, argDef := range field.Definition.Arguments {
+		value, ok, err := argDefaultedValue(field, argDef, variables)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		comparison := "eq"
+		if filterDirective := argDef.Directives.ForName("filter"); filterDirective != nil {
+			if fnArg := filterDirective.Arguments.ForName("fn"); fnArg != nil {
+				comparison = fnArg.Value.Raw
+			}
+		}
+
+		filter, err := comparisonFilter(comparison, argDef.Name, value)
+		if err != nil {
+			return nil, err
+		}
+
+		filters = append(filters, filter)
+	}
+
+	return filters, nil
+}
+
+// argDefaultedValue resolves argDef's value for this field invocation,
+// preferring the caller-supplied argument and falling back to the
+// schema-declared default. ok is false when the caller omitted the
+// argument and the schema declares no default, so it contributes no
+// filter.
+func argDefaultedValue(field *ast.Field, argDef *ast.ArgumentDefinition, variables map[string]interface{}) (value interface{}, ok bool, err error) {
+	if arg := field.Arguments.ForName(argDef.Name); arg != nil {
+		value, err = argValue(arg, variables)
+		return value, true, err
+	}
+
+	if argDef.DefaultValue != nil {
+		value, err = argDef.DefaultValue.Value(variables)
+		return value, true, err
+	}
+
+	return nil, false, nil
+}
+
+func comparisonFilter(fn string, predicate string, value interface{}) (dqlx.DQLizer, error) {
+	switch fn {
+	case "eq":
+		return dqlx.Eq(predicate, value), nil
+	case "le":
+		return dqlx.Le(predicate, value), nil
+	case "ge":
+		return dqlx.Ge(predicate, value), nil
+	case "lt":
+		return dqlx.Lt(predicate, value), nil
+	case "gt":
+		return dqlx.Gt(predicate, value), nil
+	default:
+		return nil, fmt.Errorf("dqlx/graphql: unsupported @filter fn %q", fn)
+	}
+}
+
+// argValue resolves a caller-supplied argument to its Go value,
+// resolving GraphQL $variable references against the request variables.
+// Schema-declared defaults for omitted arguments are handled by
+// argDefaultedValue, not here.
+func argValue(arg *ast.Argument, variables map[string]interface{}) (interface{}, error) {
+	switch arg.Value.Kind {
+	case ast.Variable:
+		value, ok := variables[arg.Value.Raw]
+		if !ok {
+			return nil, fmt.Errorf("dqlx/graphql: missing variable $%s", arg.Value.Raw)
+		}
+		return value, nil
+	default:
+		return arg.Value.Value(variables)
+	}
+}
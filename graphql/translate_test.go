@@ -0,0 +1,160 @@
+package graphql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/allanglen/dqlx"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const testSchema = `
+type Query {
+	users(active: Boolean = true, role: String): [User]
+}
+
+type User {
+	uid: ID!
+	name: String
+	role: String
+	friend: User
+}
+`
+
+func parseField(t *testing.T, query string) *ast.Field {
+	t.Helper()
+
+	schema, err := gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: testSchema})
+	if err != nil {
+		t.Fatalf("failed to load schema: %v", err)
+	}
+
+	doc, err := gqlparser.LoadQuery(schema, query)
+	if err != nil {
+		t.Fatalf("failed to load query: %v", err)
+	}
+
+	return doc.Operations[0].SelectionSet[0].(*ast.Field)
+}
+
+func TestArgsToFiltersUsesSchemaDefault(t *testing.T) {
+	field := parseField(t, `{ users { name } }`)
+
+	filters, err := argsToFilters(field, nil)
+	if err != nil {
+		t.Fatalf("argsToFilters() error = %v", err)
+	}
+
+	if len(filters) != 1 {
+		t.Fatalf("expected 1 filter from the schema default, got %d", len(filters))
+	}
+}
+
+func TestArgsToFiltersPrefersSuppliedArgument(t *testing.T) {
+	field := parseField(t, `{ users(active: false, role: "admin") { name } }`)
+
+	filters, err := argsToFilters(field, nil)
+	if err != nil {
+		t.Fatalf("argsToFilters() error = %v", err)
+	}
+
+	if len(filters) != 2 {
+		t.Fatalf("expected 2 filters, got %d", len(filters))
+	}
+}
+
+func TestFieldToQueryTraversesFieldNameNotParentType(t *testing.T) {
+	field := parseField(t, `{ users { name } }`)
+
+	builder, err := fieldToQuery(field, nil)
+	if err != nil {
+		t.Fatalf("fieldToQuery() error = %v", err)
+	}
+
+	query, _, err := dqlx.QueriesToDQL(builder)
+	if err != nil {
+		t.Fatalf("QueriesToDQL() error = %v", err)
+	}
+
+	if !strings.Contains(query, "users") {
+		t.Fatalf("query = %q, want it to traverse the users field", query)
+	}
+
+	if strings.Contains(query, "Query") {
+		t.Fatalf("query = %q, want it not to mention the parent Query type", query)
+	}
+}
+
+func TestFieldToQueryUsesAliasAsBlockLabel(t *testing.T) {
+	aliased := parseField(t, `{ f: users { name } }`)
+	plain := parseField(t, `{ users { name } }`)
+
+	aliasedBuilder, err := fieldToQuery(aliased, nil)
+	if err != nil {
+		t.Fatalf("fieldToQuery() error = %v", err)
+	}
+
+	plainBuilder, err := fieldToQuery(plain, nil)
+	if err != nil {
+		t.Fatalf("fieldToQuery() error = %v", err)
+	}
+
+	aliasedQuery, _, err := dqlx.QueriesToDQL(aliasedBuilder)
+	if err != nil {
+		t.Fatalf("QueriesToDQL() error = %v", err)
+	}
+
+	plainQuery, _, err := dqlx.QueriesToDQL(plainBuilder)
+	if err != nil {
+		t.Fatalf("QueriesToDQL() error = %v", err)
+	}
+
+	if aliasedQuery == plainQuery {
+		t.Fatalf("expected the alias to change the generated query, got identical output %q for both", aliasedQuery)
+	}
+}
+
+func TestRejectUnsupportedDirectives(t *testing.T) {
+	cases := []struct {
+		name      string
+		directive string
+	}{
+		{name: "recurse", directive: "recurse"},
+		{name: "shortestPath", directive: "shortestPath"},
+		{name: "facets", directive: "facets"},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			schema, err := gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: `
+directive @recurse on FIELD_DEFINITION
+directive @shortestPath on FIELD_DEFINITION
+directive @facets on FIELD_DEFINITION
+
+type Query {
+	users: [User] @` + testCase.directive + `
+}
+
+type User {
+	uid: ID!
+	name: String
+}
+`})
+			if err != nil {
+				t.Fatalf("failed to load schema: %v", err)
+			}
+
+			doc, err := gqlparser.LoadQuery(schema, `{ users { name } }`)
+			if err != nil {
+				t.Fatalf("failed to load query: %v", err)
+			}
+
+			field := doc.Operations[0].SelectionSet[0].(*ast.Field)
+
+			if _, err := fieldToQuery(field, nil); err == nil {
+				t.Fatalf("expected fieldToQuery() to reject @%s", testCase.directive)
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package dqlx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/dgraph-io/dgo/v200/protos/api"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+type HTTPExecutor struct {
+	baseURL string
+	client  *http.Client
+
+	authToken   string
+	accessToken string
+	readOnly    bool
+	bestEffort  bool
+}
+
+type HTTPExecutorOptionFn func(executor *HTTPExecutor)
+
+func WithHTTPClient(client *http.Client) HTTPExecutorOptionFn {
+	return func(executor *HTTPExecutor) {
+		executor.client = client
+	}
+}
+
+func WithAuthToken(token string) HTTPExecutorOptionFn {
+	return func(executor *HTTPExecutor) {
+		executor.authToken = token
+	}
+}
+
+func WithAccessToken(token string) HTTPExecutorOptionFn {
+	return func(executor *HTTPExecutor) {
+		executor.accessToken = token
+	}
+}
+
+func WithHTTPReadOnly(readOnly bool) HTTPExecutorOptionFn {
+	return func(executor *HTTPExecutor) {
+		executor.readOnly = readOnly
+	}
+}
+
+func WithHTTPBestEffort(bestEffort bool) HTTPExecutorOptionFn {
+	return func(executor *HTTPExecutor) {
+		executor.bestEffort = bestEffort
+	}
+}
+
+// NewHTTPExecutor creates an executor that talks to Dgraph's HTTP API
+// (/query and /mutate) instead of going through the dgo gRPC client. This
+// is the only option for backends that only expose HTTP, such as Dgraph
+// Cloud or slash-graphql.
+func NewHTTPExecutor(baseURL string, options ...HTTPExecutorOptionFn) *HTTPExecutor {
+	executor := &HTTPExecutor{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  http.DefaultClient,
+	}
+
+	for _, option := range options {
+		option(executor)
+	}
+
+	return executor
+}
+
+type httpQueryRequest struct {
+	Query     string            `json:"query"`
+	Variables map[string]string `json:"variables,omitempty"`
+}
+
+func (executor HTTPExecutor) ExecuteQueries(ctx context.Context, queries ...QueryBuilder) (*Response, error) {
+	query, variables, err := QueriesToDQL(queries...)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(httpQueryRequest{
+		Query:     query,
+		Variables: variables,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := executor.do(ctx, "/query", "application/json", body)
+	if err != nil {
+		return nil, err
+	}
+
+	return executor.toResponse(resp, queries...)
+}
+
+// httpMutationRequest is the JSON body Dgraph's HTTP /mutate endpoint
+// expects for a JSON-payload upsert: the query block providing any
+// uid(var) bindings, the set/delete payloads, and the @if(...) guard,
+// all alongside each other instead of split across separate gRPC
+// api.Request fields (compare DGoExecutor.ExecuteMutations).
+type httpMutationRequest struct {
+	Query  string          `json:"query,omitempty"`
+	Set    json.RawMessage `json:"set,omitempty"`
+	Delete json.RawMessage `json:"delete,omitempty"`
+	Cond   string          `json:"cond,omitempty"`
+}
+
+func (executor HTTPExecutor) ExecuteMutations(ctx context.Context, mutations ...MutationBuilder) (*Response, error) {
+	if len(mutations) != 1 {
+		return nil, errors.New("HTTPExecutor.ExecuteMutations only supports a single mutation per call")
+	}
+
+	mutation := mutations[0]
+	queries := []QueryBuilder{mutation.query}
+
+	query, _, err := QueriesToDQL(mutation.query)
+	if err != nil {
+		return nil, err
+	}
+	if IsEmptyQuery(query) {
+		query = ""
+	}
+
+	var condition string
+	if mutation.condition != nil {
+		conditionDql, _, err := mutation.condition.ToDQL()
+		if err != nil {
+			return nil, err
+		}
+		condition = conditionDql
+	}
+
+	setData, deleteData, err := mutationData(mutation)
+	if err != nil {
+		return nil, err
+	}
+
+	hasNQuads := mutation.setNQuads != "" || mutation.delNQuads != ""
+	hasJSON := setData != nil || deleteData != nil
+
+	if hasNQuads && hasJSON {
+		return nil, errors.New("dqlx: HTTPExecutor.ExecuteMutations does not support mixing JSON and RDF N-Quads payloads in the same mutation")
+	}
+
+	contentType := "application/json"
+	var body []byte
+
+	switch {
+	case hasNQuads:
+		contentType = "application/rdf"
+		body = []byte(nquadsMutationBody(mutation, query, condition))
+	default:
+		body, err = json.Marshal(httpMutationRequest{
+			Query:  query,
+			Set:    setData,
+			Delete: deleteData,
+			Cond:   condition,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := executor.do(ctx, "/mutate?commitNow=true", contentType, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return executor.toResponse(resp, queries...)
+}
+
+func (executor HTTPExecutor) do(ctx context.Context, path string, contentType string, body []byte) (*httpResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, executor.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+
+	if executor.authToken != "" {
+		req.Header.Set("Dgraph-AuthToken", executor.authToken)
+	}
+
+	if executor.accessToken != "" {
+		req.Header.Set("X-Dgraph-AccessToken", executor.accessToken)
+	}
+
+	resp, err := executor.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	payload, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("dqlx: dgraph http request failed with status %d: %s", resp.StatusCode, payload)
+	}
+
+	var parsed httpResponse
+	if err := json.Unmarshal(payload, &parsed); err != nil {
+		return nil, err
+	}
+
+	if len(parsed.Errors) > 0 {
+		return nil, errors.New(parsed.Errors[0].Message)
+	}
+
+	return &parsed, nil
+}
+
+type httpResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func (executor HTTPExecutor) toResponse(resp *httpResponse, queries ...QueryBuilder) (*Response, error) {
+	var dataPathKey string
+
+	if len(queries) == 1 {
+		dataPathKey = queries[0].rootEdge.Name
+	} else {
+		dataPathKey = ""
+	}
+
+	queryResponse := &Response{
+		dataKeyPath: dataPathKey,
+		Raw: &api.Response{
+			Json: resp.Data,
+		},
+	}
+
+	queries = ensureUniqueQueryNames(queries)
+
+	for _, queryBuilder := range queries {
+		if queryBuilder.unmarshalInto == nil {
+			continue
+		}
+		singleResponse := &Response{
+			dataKeyPath: queryBuilder.rootEdge.Name,
+			Raw:         queryResponse.Raw,
+		}
+
+		if err := singleResponse.Unmarshal(queryBuilder.unmarshalInto); err != nil {
+			return nil, err
+		}
+	}
+
+	return queryResponse, nil
+}
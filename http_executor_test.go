@@ -0,0 +1,214 @@
+package dqlx
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// fakeCondition is a minimal DQLizer used to stand in for an @if(...)
+// mutation condition without depending on the full filter-building API.
+type fakeCondition struct {
+	dql string
+}
+
+func (condition fakeCondition) ToDQL() (string, []interface{}, error) {
+	return condition.dql, nil, nil
+}
+
+func TestHTTPExecutorExecuteQueries(t *testing.T) {
+	var gotPath, gotContentType string
+	var gotBody httpQueryRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotContentType = r.Header.Get("Content-Type")
+
+		payload, _ := ioutil.ReadAll(r.Body)
+		_ = json.Unmarshal(payload, &gotBody)
+
+		_, _ = w.Write([]byte(`{"data":{"users":[{"uid":"0x1","name":"a"}]}}`))
+	}))
+	defer server.Close()
+
+	executor := NewHTTPExecutor(server.URL)
+
+	query := Query(EdgeFromQuery("users", "User")).Select("name")
+
+	resp, err := executor.ExecuteQueries(context.Background(), query)
+	if err != nil {
+		t.Fatalf("ExecuteQueries() error = %v", err)
+	}
+
+	if gotPath != "/query" {
+		t.Fatalf("path = %q, want /query", gotPath)
+	}
+
+	if gotContentType != "application/json" {
+		t.Fatalf("content-type = %q, want application/json", gotContentType)
+	}
+
+	if !strings.Contains(gotBody.Query, "users") {
+		t.Fatalf("request body query = %q, want it to mention the users edge", gotBody.Query)
+	}
+
+	if resp.Raw == nil {
+		t.Fatal("expected a response")
+	}
+}
+
+func TestHTTPExecutorExecuteMutationsJSON(t *testing.T) {
+	var gotPath, gotContentType string
+	var gotBody httpMutationRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotContentType = r.Header.Get("Content-Type")
+
+		payload, _ := ioutil.ReadAll(r.Body)
+		_ = json.Unmarshal(payload, &gotBody)
+
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	defer server.Close()
+
+	executor := NewHTTPExecutor(server.URL)
+
+	mutation := MutationBuilder{
+		query:     Query(EdgeFromQuery("q", "User")).Filter(Eq("email", "a@b.com")),
+		condition: fakeCondition{dql: "eq(len(v), 1)"},
+		setData:   map[string]interface{}{"name": "updated"},
+	}
+
+	_, err := executor.ExecuteMutations(context.Background(), mutation)
+	if err != nil {
+		t.Fatalf("ExecuteMutations() error = %v", err)
+	}
+
+	if gotPath != "/mutate" && !strings.HasPrefix(gotPath, "/mutate") {
+		t.Fatalf("path = %q, want /mutate", gotPath)
+	}
+
+	if gotContentType != "application/json" {
+		t.Fatalf("content-type = %q, want application/json", gotContentType)
+	}
+
+	if gotBody.Cond != "eq(len(v), 1)" {
+		t.Fatalf("cond = %q, want %q", gotBody.Cond, "eq(len(v), 1)")
+	}
+
+	if len(gotBody.Set) == 0 {
+		t.Fatal("expected a set payload in the request body")
+	}
+
+	if !strings.Contains(gotBody.Query, "q") {
+		t.Fatalf("query = %q, want it to embed the upsert query", gotBody.Query)
+	}
+}
+
+func TestHTTPExecutorExecuteMutationsNQuads(t *testing.T) {
+	var gotContentType, gotBody string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotContentType = r.Header.Get("Content-Type")
+
+		payload, _ := ioutil.ReadAll(r.Body)
+		gotBody = string(payload)
+
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	defer server.Close()
+
+	executor := NewHTTPExecutor(server.URL)
+
+	mutation := MutationBuilder{
+		setNQuads: `uid(v) <name> "updated" .`,
+	}
+
+	_, err := executor.ExecuteMutations(context.Background(), mutation)
+	if err != nil {
+		t.Fatalf("ExecuteMutations() error = %v", err)
+	}
+
+	if gotContentType != "application/rdf" {
+		t.Fatalf("content-type = %q, want application/rdf", gotContentType)
+	}
+
+	if !strings.Contains(gotBody, "set {") {
+		t.Fatalf("body = %q, want a set block", gotBody)
+	}
+}
+
+func TestHTTPExecutorExecuteMutationsRejectsMixedPayloads(t *testing.T) {
+	executor := NewHTTPExecutor("http://example.invalid")
+
+	mutation := MutationBuilder{
+		setData:   map[string]interface{}{"name": "a"},
+		setNQuads: `uid(v) <name> "a" .`,
+	}
+
+	_, err := executor.ExecuteMutations(context.Background(), mutation)
+	if err == nil {
+		t.Fatal("expected an error when mixing JSON and RDF payloads")
+	}
+}
+
+func TestHTTPExecutorErrorStatusCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`bad request`))
+	}))
+	defer server.Close()
+
+	executor := NewHTTPExecutor(server.URL)
+
+	_, err := executor.ExecuteQueries(context.Background(), Query(EdgeFromQuery("users", "User")))
+	if err == nil {
+		t.Fatal("expected an error for a non-200 response")
+	}
+}
+
+func TestHTTPExecutorGraphQLErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"errors":[{"message":"boom"}]}`))
+	}))
+	defer server.Close()
+
+	executor := NewHTTPExecutor(server.URL)
+
+	_, err := executor.ExecuteQueries(context.Background(), Query(EdgeFromQuery("users", "User")))
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("ExecuteQueries() error = %v, want it to mention %q", err, "boom")
+	}
+}
+
+func TestHTTPExecutorAuthHeaders(t *testing.T) {
+	var gotAuthToken, gotAccessToken string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuthToken = r.Header.Get("Dgraph-AuthToken")
+		gotAccessToken = r.Header.Get("X-Dgraph-AccessToken")
+
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	defer server.Close()
+
+	executor := NewHTTPExecutor(server.URL, WithAuthToken("secret"), WithAccessToken("token"))
+
+	_, err := executor.ExecuteQueries(context.Background(), Query(EdgeFromQuery("users", "User")))
+	if err != nil {
+		t.Fatalf("ExecuteQueries() error = %v", err)
+	}
+
+	if gotAuthToken != "secret" {
+		t.Fatalf("Dgraph-AuthToken = %q, want %q", gotAuthToken, "secret")
+	}
+
+	if gotAccessToken != "token" {
+		t.Fatalf("X-Dgraph-AccessToken = %q, want %q", gotAccessToken, "token")
+	}
+}
@@ -0,0 +1,52 @@
+package dqlx
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetNQuads sets the mutation's set block to a raw RDF N-Quads payload,
+// bypassing the SetJson path entirely. See NQuad for why this exists.
+func (mutationBuilder MutationBuilder) SetNQuads(nQuads string) MutationBuilder {
+	mutationBuilder.setNQuads = nQuads
+	return mutationBuilder
+}
+
+// DelNQuads sets the mutation's delete block to a raw RDF N-Quads payload,
+// bypassing the DeleteJson path. See SetNQuads.
+func (mutationBuilder MutationBuilder) DelNQuads(nQuads string) MutationBuilder {
+	mutationBuilder.delNQuads = nQuads
+	return mutationBuilder
+}
+
+// nquadsMutationBody renders mutation's query/cond/NQuads as the
+// Content-Type: application/rdf body HTTPExecutor sends to /mutate.
+// Unlike the gRPC api.Mutation.SetNquads/DelNquads fields -- which carry
+// the query and condition alongside the mutation on the api.Request --
+// the RDF body has to embed the upsert query block and the @if(cond)
+// guard itself, or an upsert built around uid(var) bindings silently
+// mutates unconditionally.
+func nquadsMutationBody(mutation MutationBuilder, query string, condition string) string {
+	ifClause := ""
+	if condition != "" {
+		ifClause = fmt.Sprintf(" @if(%s)", condition)
+	}
+
+	var blocks []string
+
+	if mutation.setNQuads != "" {
+		blocks = append(blocks, fmt.Sprintf("set%s {\n%s\n}", ifClause, mutation.setNQuads))
+	}
+
+	if mutation.delNQuads != "" {
+		blocks = append(blocks, fmt.Sprintf("delete%s {\n%s\n}", ifClause, mutation.delNQuads))
+	}
+
+	mutationBlock := fmt.Sprintf("{\n%s\n}", strings.Join(blocks, "\n"))
+
+	if query == "" {
+		return mutationBlock
+	}
+
+	return strings.Join([]string{query, mutationBlock}, "\n")
+}
@@ -0,0 +1,54 @@
+package dqlx
+
+import "testing"
+
+func TestNquadsMutationBody(t *testing.T) {
+	cases := []struct {
+		name      string
+		mutation  MutationBuilder
+		query     string
+		condition string
+		want      string
+	}{
+		{
+			name:     "set only",
+			mutation: MutationBuilder{setNQuads: `<0x1> <name> "a" .`},
+			want:     "{\nset {\n<0x1> <name> \"a\" .\n}\n}",
+		},
+		{
+			name:     "delete only",
+			mutation: MutationBuilder{delNQuads: `<0x1> <name> "a" .`},
+			want:     "{\ndelete {\n<0x1> <name> \"a\" .\n}\n}",
+		},
+		{
+			name: "set and delete",
+			mutation: MutationBuilder{
+				setNQuads: `<0x1> <name> "a" .`,
+				delNQuads: `<0x1> <age> "30" .`,
+			},
+			want: "{\nset {\n<0x1> <name> \"a\" .\n}\ndelete {\n<0x1> <age> \"30\" .\n}\n}",
+		},
+		{
+			name:      "set with condition",
+			mutation:  MutationBuilder{setNQuads: `uid(v) <name> "a" .`},
+			condition: "eq(len(v), 1)",
+			want:      "{\nset @if(eq(len(v), 1)) {\nuid(v) <name> \"a\" .\n}\n}",
+		},
+		{
+			name:     "set with upsert query",
+			mutation: MutationBuilder{setNQuads: `uid(v) <name> "a" .`},
+			query:    `query q($a: string) { v as var(func: eq(email, $a)) }`,
+			want:     "query q($a: string) { v as var(func: eq(email, $a)) }\n{\nset {\nuid(v) <name> \"a\" .\n}\n}",
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := nquadsMutationBody(testCase.mutation, testCase.query, testCase.condition)
+
+			if got != testCase.want {
+				t.Fatalf("nquadsMutationBody() = %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package dqlx
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NQuad represents a single RDF N-Quad triple, used to build upsert
+// mutations that reference blank nodes or uid(var) bindings produced by
+// the query block -- something that cannot be expressed with JSON
+// mutations.
+type NQuad struct {
+	Subject   string
+	Predicate string
+	// Object is written as-is when ObjectIsRaw is true (e.g. uid(var),
+	// another blank node, or a already-quoted literal), otherwise it is
+	// quoted and escaped as a string literal.
+	Object      string
+	ObjectIsRaw bool
+}
+
+func (nQuad NQuad) String() string {
+	object := nQuad.Object
+
+	if !nQuad.ObjectIsRaw {
+		object = fmt.Sprintf("%q", object)
+	}
+
+	return fmt.Sprintf("%s <%s> %s .", nQuad.Subject, nQuad.Predicate, object)
+}
+
+// NQuads joins a list of NQuad statements into a single RDF N-Quads
+// payload suitable for SetNQuads/DelNQuads.
+func NQuads(quads ...NQuad) string {
+	lines := make([]string, len(quads))
+
+	for index, quad := range quads {
+		lines[index] = quad.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
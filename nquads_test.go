@@ -0,0 +1,58 @@
+package dqlx
+
+import "testing"
+
+func TestNQuadString(t *testing.T) {
+	cases := []struct {
+		name string
+		quad NQuad
+		want string
+	}{
+		{
+			name: "quoted string object",
+			quad: NQuad{Subject: "_:user1", Predicate: "name", Object: `Wren"ch`},
+			want: `_:user1 <name> "Wren\"ch" .`,
+		},
+		{
+			name: "raw uid(var) object",
+			quad: NQuad{Subject: "_:user1", Predicate: "best_friend", Object: "uid(v)", ObjectIsRaw: true},
+			want: "_:user1 <best_friend> uid(v) .",
+		},
+		{
+			name: "raw blank node object",
+			quad: NQuad{Subject: "_:user1", Predicate: "manager", Object: "_:user2", ObjectIsRaw: true},
+			want: "_:user1 <manager> _:user2 .",
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := testCase.quad.String()
+
+			if got != testCase.want {
+				t.Fatalf("NQuad.String() = %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestNQuads(t *testing.T) {
+	got := NQuads(
+		NQuad{Subject: "_:user1", Predicate: "name", Object: "Wrench"},
+		NQuad{Subject: "_:user1", Predicate: "best_friend", Object: "uid(v)", ObjectIsRaw: true},
+	)
+
+	want := "_:user1 <name> \"Wrench\" .\n_:user1 <best_friend> uid(v) ."
+
+	if got != want {
+		t.Fatalf("NQuads() = %q, want %q", got, want)
+	}
+}
+
+func TestNQuadsEmpty(t *testing.T) {
+	got := NQuads()
+
+	if got != "" {
+		t.Fatalf("NQuads() = %q, want empty string", got)
+	}
+}
@@ -2,6 +2,7 @@ package dqlx
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strings"
@@ -14,8 +15,9 @@ type Operation interface {
 }
 
 type queryOperation struct {
-	operations []Operation
-	variables  []Operation
+	operations     []Operation
+	variables      []Operation
+	namedVariables []QueryVariable
 }
 
 func QueriesToDQL(queries ...QueryBuilder) (query string, args map[string]string, err error) {
@@ -30,6 +32,8 @@ func QueriesToDQL(queries ...QueryBuilder) (query string, args map[string]string
 		}
 	}
 
+	mainOperation.namedVariables = collectNamedVariables(queries)
+
 	return mainOperation.ToDQL()
 }
 
@@ -61,6 +65,12 @@ func (grammar queryOperation) ToDQL() (query string, variables map[string]string
 	})
 	variables, placeholders := toVariables(rawVariables)
 
+	namedVariables, namedPlaceholders := namedVariablePlaceholders(grammar.namedVariables)
+	for name, value := range namedVariables {
+		variables[name] = value
+	}
+	placeholders = append(placeholders, namedPlaceholders...)
+
 	writer := bytes.Buffer{}
 	writer.WriteString(fmt.Sprintf("query %s(%s) {", queryName, strings.Join(placeholders, ", ")))
 	writer.WriteString(" " + query)
@@ -93,6 +103,15 @@ func toVariableValue(value interface{}) string {
 	case *time.Time:
 		return val.Format(time.RFC3339)
 	default:
+		if isListType(val) {
+			// Go's default "%v" formatting of a slice ("[0x1 0x2]") is
+			// space-separated and unquoted, which is not a valid DQL
+			// list literal -- JSON-encode it instead (e.g. ["0x1","0x2"]),
+			// which DQL also accepts for list-typed variables.
+			if encoded, err := json.Marshal(val); err == nil {
+				return string(encoded)
+			}
+		}
 		return fmt.Sprintf("%v", val)
 	}
 }
@@ -113,6 +132,9 @@ func ensureUniqueQueryNames(queries []QueryBuilder) []QueryBuilder {
 	return uniqueQueries
 }
 
+// goTypeToDQLType is the reflection-based fallback used for the legacy
+// positional "??" placeholders. See QueryVariable for why this can guess
+// wrong and when to prefer WithVariable instead.
 func goTypeToDQLType(value interface{}) string {
 	switch value.(type) {
 	case string:
@@ -0,0 +1,33 @@
+package dqlx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestToVariableValue(t *testing.T) {
+	someTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	cases := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{"string", "0x1", "0x1"},
+		{"int", 42, "42"},
+		{"time.Time", someTime, someTime.Format(time.RFC3339)},
+		{"string slice", []string{"0x1", "0x2"}, `["0x1","0x2"]`},
+		{"int slice", []int{1, 2, 3}, `[1,2,3]`},
+		{"empty slice", []string{}, `[]`},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := toVariableValue(testCase.value)
+
+			if got != testCase.want {
+				t.Fatalf("toVariableValue(%#v) = %q, want %q", testCase.value, got, testCase.want)
+			}
+		})
+	}
+}
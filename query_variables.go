@@ -0,0 +1,72 @@
+package dqlx
+
+import "fmt"
+
+// QueryVariable is a named DQL query variable declared up-front with an
+// explicit type, as opposed to the positional "??" placeholders resolved
+// via goTypeToDQLType. Declaring the type avoids the reflection fallback
+// silently defaulting to "string" for types such as uint64, []string or
+// json.Number, which produces invalid queries when the predicate is e.g.
+// int or uid.
+type QueryVariable struct {
+	Name    string
+	Value   interface{}
+	DQLType string
+}
+
+// WithVariable declares a named query variable ($name) with an explicit
+// DQL type. The variable can then be referenced from filters/functions
+// via RawVariable(name), e.g.:
+//
+//	dqlx.Query(...).
+//		WithVariable("ids", ids, "[uid]").
+//		Filter(dqlx.Eq("uid", dqlx.RawVariable("ids")))
+func (queryBuilder QueryBuilder) WithVariable(name string, value interface{}, dqlType string) QueryBuilder {
+	queryBuilder.queryVariables = append(queryBuilder.queryVariables, QueryVariable{
+		Name:    name,
+		Value:   value,
+		DQLType: dqlType,
+	})
+
+	return queryBuilder
+}
+
+// rawVariable references an already-declared named variable ($name)
+// without going through the positional placeholder/reflection machinery.
+type rawVariable struct {
+	name string
+}
+
+// RawVariable returns a DQLizer that renders a reference ($name) to a
+// variable previously declared with WithVariable, for use inside filters
+// and functions.
+func RawVariable(name string) DQLizer {
+	return rawVariable{name: name}
+}
+
+func (variable rawVariable) ToDQL() (string, []interface{}, error) {
+	return fmt.Sprintf("$%s", variable.name), nil, nil
+}
+
+func collectNamedVariables(queries []QueryBuilder) []QueryVariable {
+	var namedVariables []QueryVariable
+
+	for _, query := range queries {
+		namedVariables = append(namedVariables, query.queryVariables...)
+	}
+
+	return namedVariables
+}
+
+func namedVariablePlaceholders(namedVariables []QueryVariable) (variables map[string]string, placeholders []string) {
+	variables = map[string]string{}
+	placeholders = make([]string, len(namedVariables))
+
+	for index, namedVariable := range namedVariables {
+		variableName := fmt.Sprintf("$%s", namedVariable.Name)
+		variables[variableName] = toVariableValue(namedVariable.Value)
+		placeholders[index] = fmt.Sprintf("$%s:%s", namedVariable.Name, namedVariable.DQLType)
+	}
+
+	return variables, placeholders
+}
@@ -0,0 +1,119 @@
+package dqlx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// Paginate configures the query to be issued by
+// DGoExecutor.ExecutePaginated as successive first/after pages of
+// pageSize nodes instead of a single unbounded query, so Response.Stream
+// can decode results without buffering the entire response in memory.
+// ExecuteQueries does not read the page size.
+func (queryBuilder QueryBuilder) Paginate(pageSize int) QueryBuilder {
+	queryBuilder.pageSize = pageSize
+	return queryBuilder
+}
+
+// Stream decodes the root edge of the response incrementally, invoking
+// handler once per decoded element instead of materialising the whole
+// array in memory first. Unlike Unmarshal, which loads resp.Raw.Json
+// into a map[string]interface{} before re-decoding it through
+// mapstructure, Stream walks the JSON token-by-token and only ever holds
+// a single element at a time -- it's the one to reach for on exports
+// that run into the millions of nodes.
+//
+// target must be a pointer to the struct type each element decodes into;
+// handler receives a new *target value on every call.
+func (response Response) Stream(target interface{}, handler func(item interface{}) error) error {
+	decoder := json.NewDecoder(bytes.NewReader(response.Raw.Json))
+
+	if err := skipToRootArray(decoder, response.dataKeyPath); err != nil {
+		return err
+	}
+
+	for decoder.More() {
+		item, err := newTargetValue(target)
+		if err != nil {
+			return err
+		}
+
+		if err := decoder.Decode(item); err != nil {
+			return err
+		}
+
+		if err := handler(item); err != nil {
+			return err
+		}
+	}
+
+	// consume the closing "]"
+	_, err := decoder.Token()
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// newTargetValue allocates a fresh zero value of the same type target
+// points to, so each decoded element gets its own instance rather than
+// every handler call sharing (and clobbering) one.
+func newTargetValue(target interface{}) (interface{}, error) {
+	targetType := reflect.TypeOf(target)
+
+	if targetType == nil || targetType.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("dqlx: Stream target must be a pointer, got %T", target)
+	}
+
+	return reflect.New(targetType.Elem()).Interface(), nil
+}
+
+// skipToRootArray advances decoder past every token up to and including
+// the opening "[" of the data.<dataKeyPath> array (or just data.<root
+// key> when dataKeyPath is unset and the payload has a single root key).
+func skipToRootArray(decoder *json.Decoder, dataKeyPath string) error {
+	depth := 0
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		if delim, ok := token.(json.Delim); ok {
+			switch delim {
+			case '{':
+				depth++
+			case '}':
+				depth--
+			case '[':
+				if depth == 1 && dataKeyPath == "" {
+					return nil
+				}
+			}
+			continue
+		}
+
+		key, ok := token.(string)
+		if !ok || depth != 1 {
+			continue
+		}
+
+		if dataKeyPath == "" || key == dataKeyPath {
+			next, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+
+			if delim, ok := next.(json.Delim); ok && delim == '[' {
+				return nil
+			}
+
+			return fmt.Errorf("dqlx: expected %q to be a JSON array", key)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package dqlx
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/dgo/v200/protos/api"
+)
+
+type streamTestUser struct {
+	UID  string `json:"uid"`
+	Name string `json:"name"`
+}
+
+func TestResponseStream(t *testing.T) {
+	response := Response{
+		dataKeyPath: "users",
+		Raw: &api.Response{
+			Json: []byte(`{"users":[{"uid":"0x1","name":"a"},{"uid":"0x2","name":"b"}]}`),
+		},
+	}
+
+	var got []streamTestUser
+
+	err := response.Stream(&streamTestUser{}, func(item interface{}) error {
+		got = append(got, *item.(*streamTestUser))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+
+	if len(got) != 2 || got[0].UID != "0x1" || got[1].UID != "0x2" {
+		t.Fatalf("Stream() decoded = %+v, want [0x1 0x2]", got)
+	}
+}
+
+func TestResponseStreamNoDataKeyPath(t *testing.T) {
+	response := Response{
+		Raw: &api.Response{
+			Json: []byte(`{"users":[{"uid":"0x1","name":"a"}]}`),
+		},
+	}
+
+	var got []streamTestUser
+
+	err := response.Stream(&streamTestUser{}, func(item interface{}) error {
+		got = append(got, *item.(*streamTestUser))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+
+	if len(got) != 1 || got[0].UID != "0x1" {
+		t.Fatalf("Stream() decoded = %+v, want [0x1]", got)
+	}
+}
+
+func TestResponseStreamEmptyArray(t *testing.T) {
+	response := Response{
+		dataKeyPath: "users",
+		Raw: &api.Response{
+			Json: []byte(`{"users":[]}`),
+		},
+	}
+
+	count := 0
+
+	err := response.Stream(&streamTestUser{}, func(item interface{}) error {
+		count++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+
+	if count != 0 {
+		t.Fatalf("Stream() invoked handler %d times, want 0", count)
+	}
+}